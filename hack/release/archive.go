@@ -0,0 +1,183 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Packager packages a built binary and its accompanying files into a
+// single release archive. It is an interface so the GitHub upload flow
+// can be tested without producing real archives.
+type Packager interface {
+	Package(target Target, binaryPath string, extraFiles []string, outputDir string) (archivePath string, err error)
+}
+
+// archivePackager packages Unix targets as tar.gz and Windows targets
+// as zip, per the platform's convention.
+type archivePackager struct{}
+
+// NewPackager returns the default Packager.
+func NewPackager() Packager {
+	return archivePackager{}
+}
+
+func (archivePackager) Package(target Target, binaryPath string, extraFiles []string, outputDir string) (string, error) {
+	archivePath := filepath.Join(outputDir, target.Archive())
+
+	files := append([]string{binaryPath}, extraFiles...)
+
+	var err error
+	if target.GOOS == "windows" {
+		err = writeZip(archivePath, files)
+	} else {
+		err = writeTarGz(archivePath, files)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("package %s: %w", target.Name, err)
+	}
+
+	return archivePath, nil
+}
+
+func writeTarGz(archivePath string, files []string) error {
+	out, err := os.OpenFile(archivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gw := gzip.NewWriter(out)
+	defer gw.Close()
+
+	tw := tar.NewWriter(gw)
+	defer tw.Close()
+
+	for _, path := range files {
+		if err := addToTar(tw, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addToTar(tw *tar.Writer, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.Base(path)
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	_, err = io.Copy(tw, in)
+	return err
+}
+
+func writeZip(archivePath string, files []string) error {
+	out, err := os.OpenFile(archivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	defer zw.Close()
+
+	for _, path := range files {
+		if err := addToZip(zw, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addToZip(zw *zip.Writer, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.Base(path)
+	header.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	_, err = io.Copy(w, in)
+	return err
+}
+
+// WriteChecksums writes a SHA256SUMS file listing the sha256 of every
+// archive in archivePaths, one "sum  basename" line each.
+func WriteChecksums(path string, archivePaths []string) error {
+	out, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	for _, archivePath := range archivePaths {
+		sum, err := sha256File(archivePath)
+		if err != nil {
+			return fmt.Errorf("checksum %s: %w", archivePath, err)
+		}
+
+		if _, err := fmt.Fprintf(out, "%s  %s\n", sum, filepath.Base(archivePath)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
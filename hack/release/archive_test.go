@@ -0,0 +1,101 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPackageWritesTarGzForUnixTarget(t *testing.T) {
+	dir := t.TempDir()
+
+	binaryPath := filepath.Join(dir, "tanzu")
+	if err := os.WriteFile(binaryPath, []byte("binary contents"), 0755); err != nil {
+		t.Fatalf("write binary: %v", err)
+	}
+
+	extraPath := filepath.Join(dir, "LICENSE")
+	if err := os.WriteFile(extraPath, []byte("license text"), 0644); err != nil {
+		t.Fatalf("write extra file: %v", err)
+	}
+
+	target := Target{Name: "linux-amd64", GOOS: "linux", BinaryName: "tanzu"}
+
+	archivePath, err := NewPackager().Package(target, binaryPath, []string{extraPath}, dir)
+	if err != nil {
+		t.Fatalf("Package() error = %v", err)
+	}
+
+	if got, want := filepath.Base(archivePath), "tanzu-linux-amd64.tar.gz"; got != want {
+		t.Errorf("archive name = %q, want %q", got, want)
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+
+	names := map[string]string{}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar.Next: %v", err)
+		}
+
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("read tar entry %s: %v", header.Name, err)
+		}
+		names[header.Name] = string(contents)
+	}
+
+	if names["tanzu"] != "binary contents" {
+		t.Errorf("archive missing tanzu binary, got entries %v", names)
+	}
+	if names["LICENSE"] != "license text" {
+		t.Errorf("archive missing LICENSE, got entries %v", names)
+	}
+}
+
+func TestWriteChecksums(t *testing.T) {
+	dir := t.TempDir()
+
+	archivePath := filepath.Join(dir, "tanzu-linux-amd64.tar.gz")
+	if err := os.WriteFile(archivePath, []byte("archive contents"), 0644); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+
+	checksumsPath := filepath.Join(dir, "SHA256SUMS.txt")
+	if err := WriteChecksums(checksumsPath, []string{archivePath}); err != nil {
+		t.Fatalf("WriteChecksums() error = %v", err)
+	}
+
+	contents, err := os.ReadFile(checksumsPath)
+	if err != nil {
+		t.Fatalf("read checksums: %v", err)
+	}
+
+	if !strings.Contains(string(contents), "  tanzu-linux-amd64.tar.gz\n") {
+		t.Errorf("checksums file missing archive line, got %q", contents)
+	}
+	if len(strings.Fields(string(contents))) != 2 {
+		t.Errorf("expected exactly one sha+name pair, got %q", contents)
+	}
+}
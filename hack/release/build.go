@@ -0,0 +1,55 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Builder compiles a Target's binary. It is an interface so the rest of
+// the release flow can be exercised against a fake without invoking the
+// real go toolchain.
+type Builder interface {
+	Build(target Target, outputDir string) (binaryPath string, err error)
+}
+
+// goBuilder builds targets by shelling out to `go build`.
+type goBuilder struct {
+	// pkg is the import path of the main package to build, e.g. "./cmd/tanzu".
+	pkg string
+}
+
+// NewGoBuilder returns a Builder that compiles pkg for each target with
+// the real go toolchain.
+func NewGoBuilder(pkg string) Builder {
+	return goBuilder{pkg: pkg}
+}
+
+func (b goBuilder) Build(target Target, outputDir string) (string, error) {
+	binaryPath := filepath.Join(outputDir, target.Name, target.Binary())
+
+	if err := os.MkdirAll(filepath.Dir(binaryPath), 0755); err != nil {
+		return "", fmt.Errorf("mkdir %s: %w", filepath.Dir(binaryPath), err)
+	}
+
+	cmd := exec.Command("go", "build", "-o", binaryPath, b.pkg)
+	cmd.Env = append(os.Environ(), "GOOS="+target.GOOS, "GOARCH="+target.GOARCH)
+
+	if len(target.CGOFlags) > 0 {
+		cmd.Env = append(cmd.Env, "CGO_ENABLED=1")
+		cmd.Env = append(cmd.Env, target.CGOFlags...)
+	} else {
+		cmd.Env = append(cmd.Env, "CGO_ENABLED=0")
+	}
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("go build %s: %w\n%s", target.Name, err, out)
+	}
+
+	return binaryPath, nil
+}
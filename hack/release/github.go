@@ -0,0 +1,302 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	githubAPIBaseURL = "https://api.github.com"
+
+	maxRetries  = 5
+	retryBaseMS = 500
+)
+
+// Release is a draft or published GitHub release.
+type Release struct {
+	ID        int64  `json:"id"`
+	TagName   string `json:"tag_name"`
+	UploadURL string `json:"upload_url"`
+}
+
+// Asset is a file attached to a GitHub release.
+type Asset struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// ReleaseClient drives the GitHub REST API for cutting a release. It is
+// an interface so the orchestration in main.go can be tested against a
+// fake instead of hitting GitHub.
+type ReleaseClient interface {
+	CreateDraftRelease(ctx context.Context, tag string, body string) (*Release, error)
+	GetReleaseByTag(ctx context.Context, tag string) (*Release, error)
+	UploadAsset(ctx context.Context, release *Release, path string) error
+	Publish(ctx context.Context, release *Release) error
+}
+
+// githubClient is the real ReleaseClient, talking to the GitHub REST API.
+type githubClient struct {
+	owner, repo, token string
+	httpClient         *http.Client
+	baseURL            string
+}
+
+// NewGitHubClient returns a ReleaseClient for owner/repo authenticated
+// with token.
+func NewGitHubClient(owner, repo, token string) ReleaseClient {
+	return &githubClient{
+		owner:      owner,
+		repo:       repo,
+		token:      token,
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+		baseURL:    githubAPIBaseURL,
+	}
+}
+
+func (c *githubClient) CreateDraftRelease(ctx context.Context, tag string, body string) (*Release, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"tag_name": tag,
+		"name":     tag,
+		"body":     body,
+		"draft":    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", c.baseURL, c.owner, c.repo)
+
+	resp, err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		return c.newRequest(ctx, http.MethodPost, url, bytes.NewReader(payload), "application/json")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create draft release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	release := &Release{}
+	if err := json.NewDecoder(resp.Body).Decode(release); err != nil {
+		return nil, fmt.Errorf("decode release: %w", err)
+	}
+
+	return release, nil
+}
+
+func (c *githubClient) GetReleaseByTag(ctx context.Context, tag string) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", c.baseURL, c.owner, c.repo, tag)
+
+	resp, err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		return c.newRequest(ctx, http.MethodGet, url, nil, "application/json")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", tag, err)
+	}
+	defer resp.Body.Close()
+
+	release := &Release{}
+	if err := json.NewDecoder(resp.Body).Decode(release); err != nil {
+		return nil, fmt.Errorf("decode release: %w", err)
+	}
+
+	return release, nil
+}
+
+// UploadAsset uploads the file at path to release, streaming it in a
+// single request with an explicit Content-Length (net/http only infers
+// one for in-memory readers, and GitHub requires it for asset uploads).
+// The release-asset endpoint has no chunked or resumable form, so large
+// assets are still sent as one body; if an asset with the same name
+// already exists on the release it is deleted first so re-running
+// UploadAsset replaces it instead of failing with "already_exists".
+func (c *githubClient) UploadAsset(ctx context.Context, release *Release, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if err := c.deleteAssetByName(ctx, release, filepath.Base(path)); err != nil {
+		return fmt.Errorf("replace asset %s: %w", filepath.Base(path), err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	url := uploadURL(release, path)
+
+	resp, err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		req, err := c.newRequest(ctx, http.MethodPost, url, f, contentType(path))
+		if err != nil {
+			return nil, err
+		}
+
+		req.ContentLength = info.Size()
+
+		return req, nil
+	})
+	if err != nil {
+		return fmt.Errorf("upload asset %s: %w", filepath.Base(path), err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// listAssets returns every asset currently attached to release.
+func (c *githubClient) listAssets(ctx context.Context, release *Release) ([]Asset, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/%d/assets", c.baseURL, c.owner, c.repo, release.ID)
+
+	resp, err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		return c.newRequest(ctx, http.MethodGet, url, nil, "application/json")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list assets for release %s: %w", release.TagName, err)
+	}
+	defer resp.Body.Close()
+
+	var assets []Asset
+	if err := json.NewDecoder(resp.Body).Decode(&assets); err != nil {
+		return nil, fmt.Errorf("decode assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// deleteAsset removes a single asset by ID.
+func (c *githubClient) deleteAsset(ctx context.Context, assetID int64) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/assets/%d", c.baseURL, c.owner, c.repo, assetID)
+
+	resp, err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		return c.newRequest(ctx, http.MethodDelete, url, nil, "application/json")
+	})
+	if err != nil {
+		return fmt.Errorf("delete asset %d: %w", assetID, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// deleteAssetByName deletes the asset on release named name, if any.
+func (c *githubClient) deleteAssetByName(ctx context.Context, release *Release, name string) error {
+	assets, err := c.listAssets(ctx, release)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		if asset.Name == name {
+			return c.deleteAsset(ctx, asset.ID)
+		}
+	}
+
+	return nil
+}
+
+func (c *githubClient) Publish(ctx context.Context, release *Release) error {
+	payload, err := json.Marshal(map[string]interface{}{"draft": false})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/%d", c.baseURL, c.owner, c.repo, release.ID)
+
+	resp, err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		return c.newRequest(ctx, http.MethodPatch, url, bytes.NewReader(payload), "application/json")
+	})
+	if err != nil {
+		return fmt.Errorf("publish release %s: %w", release.TagName, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (c *githubClient) newRequest(ctx context.Context, method string, url string, body io.Reader, contentType string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", contentType)
+
+	return req, nil
+}
+
+// doWithRetry sends the request built by newReq, retrying with
+// exponential backoff on 5xx responses and transport errors.
+func (c *githubClient) doWithRetry(ctx context.Context, newReq func() (*http.Request, error)) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(retryBaseMS<<uint(attempt-1)) * time.Millisecond
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("server error: %s", resp.Status)
+			resp.Body.Close()
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			return nil, fmt.Errorf("request failed: %s: %s", resp.Status, string(body))
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("gave up after %d attempts: %w", maxRetries, lastErr)
+}
+
+func uploadURL(release *Release, path string) string {
+	base := strings.SplitN(release.UploadURL, "{", 2)[0]
+	return fmt.Sprintf("%s?name=%s", base, filepath.Base(path))
+}
+
+func contentType(path string) string {
+	if ct := mime.TypeByExtension(filepath.Ext(path)); ct != "" {
+		return ct
+	}
+
+	return "application/octet-stream"
+}
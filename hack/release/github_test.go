@@ -0,0 +1,149 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) (*githubClient, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewGitHubClient("owner", "repo", "token").(*githubClient)
+	client.baseURL = server.URL
+	client.httpClient = server.Client()
+
+	return client, server
+}
+
+func TestUploadAssetSetsContentLength(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "SHA256SUMS.txt")
+	if err := os.WriteFile(path, []byte("deadbeef  archive.tar.gz\n"), 0644); err != nil {
+		t.Fatalf("write asset file: %v", err)
+	}
+
+	var gotContentLength int64
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/releases/1/assets":
+			json.NewEncoder(w).Encode([]Asset{})
+		case r.Method == http.MethodPost && r.URL.Path == "/upload":
+			gotContentLength = r.ContentLength
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	release := &Release{ID: 1, TagName: "v1.0.0", UploadURL: client.baseURL + "/upload{?name,label}"}
+
+	if err := client.UploadAsset(context.Background(), release, path); err != nil {
+		t.Fatalf("UploadAsset() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat asset file: %v", err)
+	}
+
+	if gotContentLength != info.Size() {
+		t.Errorf("Content-Length = %d, want %d", gotContentLength, info.Size())
+	}
+}
+
+func TestUploadAssetReplacesExistingAssetByName(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "SHA256SUMS.txt")
+	if err := os.WriteFile(path, []byte("deadbeef  archive.tar.gz\n"), 0644); err != nil {
+		t.Fatalf("write asset file: %v", err)
+	}
+
+	var deletedAssetID int64
+	var deleteCalled, uploadCalled bool
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/releases/1/assets":
+			json.NewEncoder(w).Encode([]Asset{{ID: 42, Name: "SHA256SUMS.txt"}, {ID: 7, Name: "other.tar.gz"}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/repos/owner/repo/releases/assets/42":
+			deleteCalled = true
+			deletedAssetID = 42
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/upload":
+			if !deleteCalled {
+				t.Errorf("upload happened before the existing asset was deleted")
+			}
+			uploadCalled = true
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	release := &Release{ID: 1, TagName: "v1.0.0", UploadURL: client.baseURL + "/upload{?name,label}"}
+
+	if err := client.UploadAsset(context.Background(), release, path); err != nil {
+		t.Fatalf("UploadAsset() error = %v", err)
+	}
+
+	if !deleteCalled || deletedAssetID != 42 {
+		t.Errorf("expected delete of asset 42, deleteCalled=%v deletedAssetID=%d", deleteCalled, deletedAssetID)
+	}
+	if !uploadCalled {
+		t.Errorf("expected upload to happen")
+	}
+}
+
+func TestDoWithRetryRetriesOn5xx(t *testing.T) {
+	attempts := 0
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(&Release{ID: 1, TagName: "v1.0.0"})
+	}))
+
+	release, err := client.GetReleaseByTag(context.Background(), "v1.0.0")
+	if err != nil {
+		t.Fatalf("GetReleaseByTag() error = %v", err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if release.TagName != "v1.0.0" {
+		t.Errorf("TagName = %q, want v1.0.0", release.TagName)
+	}
+}
+
+func TestDoWithRetryReturnsImmediatelyOn4xx(t *testing.T) {
+	attempts := 0
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	if _, err := client.GetReleaseByTag(context.Background(), "v1.0.0"); err == nil {
+		t.Fatal("expected error for 404 response")
+	}
+
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (no retry on 4xx)", attempts)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command release drives a GitHub release once hack/tags has produced a
+// new version: it builds every target in DefaultTargets, packages each
+// as an OS-appropriate archive alongside LICENSE, README.md and a
+// SHA256SUMS file, then creates a draft GitHub release, uploads every
+// archive, and publishes it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// checksumsFilename is the name of the checksums file written alongside
+// archives and uploaded as a release asset, both on a full run and with
+// -checksum-only.
+const checksumsFilename = "SHA256SUMS.txt"
+
+func main() {
+	var tag string
+	flag.StringVar(&tag, "tag", "", "The tag to release, e.g. v1.2.0")
+
+	var pkg string
+	flag.StringVar(&pkg, "pkg", "./cmd/tanzu", "Import path of the main package to build")
+
+	var repo string
+	flag.StringVar(&repo, "repo", "", "GitHub repository as owner/name")
+
+	var token string
+	flag.StringVar(&token, "token", os.Getenv("GITHUB_TOKEN"), "GitHub token, defaults to GITHUB_TOKEN")
+
+	var outputDir string
+	flag.StringVar(&outputDir, "output-dir", "dist", "Directory to build and package artifacts into")
+
+	var checksumOnly bool
+	flag.BoolVar(&checksumOnly, "checksum-only", false, "Only regenerate and re-upload SHA256SUMS.txt for an existing release")
+
+	flag.Parse()
+
+	if tag == "" {
+		fmt.Printf("Must supply -tag\n")
+		os.Exit(1)
+	}
+
+	if err := run(tag, pkg, repo, token, outputDir, checksumOnly); err != nil {
+		fmt.Printf("release failed. Err: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Succeeded\n")
+}
+
+func run(tag string, pkg string, repo string, token string, outputDir string, checksumOnly bool) error {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client := NewGitHubClient(owner, name, token)
+
+	if checksumOnly {
+		return republishChecksums(ctx, client, tag, outputDir)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", outputDir, err)
+	}
+
+	builder := NewGoBuilder(pkg)
+	packager := NewPackager()
+	extraFiles := []string{"LICENSE", "README.md"}
+
+	var archives []string
+
+	for _, target := range DefaultTargets {
+		fmt.Printf("Building %s\n", target.Name)
+
+		binaryPath, err := builder.Build(target, outputDir)
+		if err != nil {
+			return err
+		}
+
+		archivePath, err := packager.Package(target, binaryPath, extraFiles, outputDir)
+		if err != nil {
+			return err
+		}
+
+		archives = append(archives, archivePath)
+	}
+
+	checksumsPath := filepath.Join(outputDir, checksumsFilename)
+	if err := WriteChecksums(checksumsPath, archives); err != nil {
+		return err
+	}
+	archives = append(archives, checksumsPath)
+
+	fmt.Printf("Creating draft release %s\n", tag)
+
+	release, err := client.CreateDraftRelease(ctx, tag, "")
+	if err != nil {
+		return err
+	}
+
+	for _, archivePath := range archives {
+		fmt.Printf("Uploading %s\n", filepath.Base(archivePath))
+
+		if err := client.UploadAsset(ctx, release, archivePath); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Publishing release %s\n", tag)
+
+	return client.Publish(ctx, release)
+}
+
+// republishChecksums regenerates checksumsFilename from the archives
+// already sitting in outputDir and re-uploads just that file, replacing
+// the existing asset of the same name on the release.
+func republishChecksums(ctx context.Context, client ReleaseClient, tag string, outputDir string) error {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", outputDir, err)
+	}
+
+	var archives []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		if filepath.Ext(name) == ".gz" || filepath.Ext(name) == ".zip" {
+			archives = append(archives, filepath.Join(outputDir, name))
+		}
+	}
+
+	checksumsPath := filepath.Join(outputDir, checksumsFilename)
+	if err := WriteChecksums(checksumsPath, archives); err != nil {
+		return err
+	}
+
+	release, err := client.GetReleaseByTag(ctx, tag)
+	if err != nil {
+		return err
+	}
+
+	return client.UploadAsset(ctx, release, checksumsPath)
+}
+
+func splitRepo(repo string) (owner string, name string, err error) {
+	for i := 0; i < len(repo); i++ {
+		if repo[i] == '/' {
+			return repo[:i], repo[i+1:], nil
+		}
+	}
+
+	return "", "", fmt.Errorf("-repo must be owner/name, got %q", repo)
+}
@@ -0,0 +1,50 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+// Target is one GOOS/GOARCH combination this tool builds and packages,
+// modeled after Syncthing's build.go targets map.
+type Target struct {
+	// Name identifies the target in archive and log output, e.g. "linux-amd64".
+	Name string
+	// GOOS and GOARCH are passed through to `go build`.
+	GOOS   string
+	GOARCH string
+	// CGOFlags are extra "KEY=VALUE" environment entries applied when
+	// building this target (e.g. a cross CC). A non-empty slice implies
+	// CGO_ENABLED=1.
+	CGOFlags []string
+	// BinaryName is the output binary's base name, before any
+	// OS-specific suffix (".exe" on Windows) is appended.
+	BinaryName string
+}
+
+// Archive returns the filename of the packaged archive for t, without
+// a path, e.g. "mybinary-linux-amd64.tar.gz".
+func (t Target) Archive() string {
+	if t.GOOS == "windows" {
+		return t.BinaryName + "-" + t.Name + ".zip"
+	}
+
+	return t.BinaryName + "-" + t.Name + ".tar.gz"
+}
+
+// Binary returns the built binary's filename, with the ".exe" suffix on
+// Windows.
+func (t Target) Binary() string {
+	if t.GOOS == "windows" {
+		return t.BinaryName + ".exe"
+	}
+
+	return t.BinaryName
+}
+
+// DefaultTargets is the manifest of targets released for the CLI.
+var DefaultTargets = map[string]Target{
+	"linux-amd64":   {Name: "linux-amd64", GOOS: "linux", GOARCH: "amd64", BinaryName: "tanzu"},
+	"linux-arm64":   {Name: "linux-arm64", GOOS: "linux", GOARCH: "arm64", BinaryName: "tanzu"},
+	"darwin-amd64":  {Name: "darwin-amd64", GOOS: "darwin", GOARCH: "amd64", BinaryName: "tanzu"},
+	"darwin-arm64":  {Name: "darwin-arm64", GOOS: "darwin", GOARCH: "arm64", BinaryName: "tanzu"},
+	"windows-amd64": {Name: "windows-amd64", GOOS: "windows", GOARCH: "amd64", BinaryName: "tanzu"},
+}
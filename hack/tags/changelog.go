@@ -0,0 +1,295 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+const (
+	// ChangelogFullPathFilename filename
+	ChangelogFullPathFilename string = "CHANGELOG.md"
+
+	commitLogFieldSep  string = "\x1f"
+	commitLogRecordSep string = "\x1e"
+)
+
+// ErrBreakingChangeRequiresMajor is returned when the commit range
+// since the last tag contains a breaking change but the caller asked
+// for a minor bump without -force.
+var ErrBreakingChangeRequiresMajor = errors.New("breaking change requires -bump=major or -force")
+
+// changelogTypeOrder is the order conventional-commit types are
+// rendered in, breaking changes aside.
+var changelogTypeOrder = []string{"feat", "fix", "perf", "refactor", "docs"}
+
+var changelogTypeHeading = map[string]string{
+	"feat":     "Features",
+	"fix":      "Bug Fixes",
+	"perf":     "Performance Improvements",
+	"refactor": "Code Refactoring",
+	"docs":     "Documentation",
+}
+
+// Commit is a single conventional commit parsed out of `git log`.
+type Commit struct {
+	SHA         string
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// conventionalCommits walks revRange (e.g. "v1.2.0..HEAD") in the repo
+// rooted at dir, skipping merge commits, and returns every commit whose
+// subject follows the conventional-commits format. GIT_DIR, if set in
+// the environment, is honored since the git subprocess inherits it.
+func conventionalCommits(dir string, revRange string) ([]Commit, error) {
+	cmd := exec.Command("git", "log", revRange, "--no-merges",
+		"--pretty=format:%H"+commitLogFieldSep+"%s"+commitLogFieldSep+"%b"+commitLogRecordSep)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s failed: %w", revRange, err)
+	}
+
+	var commits []Commit
+
+	for _, record := range strings.Split(string(out), commitLogRecordSep) {
+		record = strings.Trim(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, commitLogFieldSep, 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		sha, subject, body := fields[0], fields[1], fields[2]
+
+		if commit, ok := parseConventionalCommit(sha, subject, body); ok {
+			commits = append(commits, commit)
+		}
+	}
+
+	return commits, nil
+}
+
+func parseConventionalCommit(sha string, subject string, body string) (Commit, bool) {
+	typ, rest, ok := cutConventionalType(subject)
+	if !ok {
+		return Commit{}, false
+	}
+
+	var scope string
+	var breaking bool
+
+	if strings.HasPrefix(rest, "(") {
+		end := strings.Index(rest, ")")
+		if end < 0 {
+			return Commit{}, false
+		}
+
+		scope = rest[1:end]
+		rest = rest[end+1:]
+	}
+
+	if strings.HasPrefix(rest, "!") {
+		breaking = true
+		rest = rest[1:]
+	}
+
+	if !strings.HasPrefix(rest, ": ") {
+		return Commit{}, false
+	}
+
+	if strings.Contains(body, "BREAKING CHANGE:") {
+		breaking = true
+	}
+
+	return Commit{
+		SHA:         sha,
+		Type:        strings.ToLower(typ),
+		Scope:       scope,
+		Description: strings.TrimSpace(rest[2:]),
+		Breaking:    breaking,
+	}, true
+}
+
+// cutConventionalType splits "type(scope)!: subject" into its leading
+// type token and the remainder, starting at the optional "(scope)".
+func cutConventionalType(subject string) (string, string, bool) {
+	for i, r := range subject {
+		if r == '(' || r == '!' || r == ':' {
+			if i == 0 {
+				return "", "", false
+			}
+
+			return subject[:i], subject[i:], true
+		}
+	}
+
+	return "", "", false
+}
+
+// hasBreakingChange reports whether any commit in commits is flagged as
+// a breaking change.
+func hasBreakingChange(commits []Commit) bool {
+	for _, commit := range commits {
+		if commit.Breaking {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderChangelogSection renders a CHANGELOG.md section for version
+// from commits, grouped by conventional-commit type with breaking
+// changes called out first. Short SHAs are hyperlinked to repoURL when
+// it is non-empty.
+func renderChangelogSection(version Version, commits []Commit, repoURL string) string {
+	var breaking, rest []Commit
+
+	for _, commit := range commits {
+		if commit.Breaking {
+			breaking = append(breaking, commit)
+		} else {
+			rest = append(rest, commit)
+		}
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## %s\n\n", version.String())
+
+	if len(breaking) > 0 {
+		b.WriteString("### BREAKING CHANGES\n\n")
+		writeChangelogEntries(&b, breaking, repoURL)
+		b.WriteString("\n")
+	}
+
+	byType := map[string][]Commit{}
+	for _, commit := range rest {
+		byType[commit.Type] = append(byType[commit.Type], commit)
+	}
+
+	for _, typ := range changelogTypeOrder {
+		entries := byType[typ]
+		if len(entries) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "### %s\n\n", changelogTypeHeading[typ])
+		writeChangelogEntries(&b, entries, repoURL)
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func writeChangelogEntries(b *strings.Builder, commits []Commit, repoURL string) {
+	var noScope []Commit
+
+	byScope := map[string][]Commit{}
+
+	for _, commit := range commits {
+		if commit.Scope == "" {
+			noScope = append(noScope, commit)
+			continue
+		}
+
+		byScope[commit.Scope] = append(byScope[commit.Scope], commit)
+	}
+
+	for _, commit := range noScope {
+		fmt.Fprintf(b, "- %s (%s)\n", commit.Description, commitLink(commit.SHA, repoURL))
+	}
+
+	scopes := make([]string, 0, len(byScope))
+	for scope := range byScope {
+		scopes = append(scopes, scope)
+	}
+
+	sort.Strings(scopes)
+
+	for _, scope := range scopes {
+		fmt.Fprintf(b, "- **%s:**\n", scope)
+
+		for _, commit := range byScope[scope] {
+			fmt.Fprintf(b, "  - %s (%s)\n", commit.Description, commitLink(commit.SHA, repoURL))
+		}
+	}
+}
+
+func commitLink(sha string, repoURL string) string {
+	short := sha
+	if len(short) > 7 {
+		short = short[:7]
+	}
+
+	if repoURL == "" {
+		return short
+	}
+
+	return fmt.Sprintf("[%s](%s/commit/%s)", short, strings.TrimRight(repoURL, "/"), sha)
+}
+
+// updateChangelog inserts section into the changelog at path, creating
+// the file if it doesn't exist yet. The new section is placed under the
+// file's leading "# " title, if it has one, rather than above it. In
+// dry-run mode it logs the section that would have been written instead
+// of touching the file; it deliberately logs rather than printing to
+// stdout, which is reserved for the -output=json Result.
+func updateChangelog(path string, section string, dryRun bool) error {
+	if dryRun {
+		logger.Info("dry run, not writing changelog", "op", "updateChangelog", "file", path, "section", section)
+		return nil
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error("ReadFile failed", "op", "updateChangelog", "file", path, "err", err)
+		return err
+	}
+
+	return os.WriteFile(path, []byte(insertChangelogSection(string(existing), section)), 0644)
+}
+
+// insertChangelogSection places section right after existing's leading
+// "# " title line (and any blank lines immediately under it), or at the
+// very top if existing has no title.
+func insertChangelogSection(existing string, section string) string {
+	title, rest, ok := splitChangelogTitle(existing)
+	if !ok {
+		return section + "\n" + existing
+	}
+
+	return title + "\n\n" + section + "\n" + rest
+}
+
+// splitChangelogTitle splits off a leading "# Title" line, along with
+// any blank lines that follow it, from the rest of the document.
+func splitChangelogTitle(doc string) (title string, rest string, ok bool) {
+	if !strings.HasPrefix(doc, "# ") {
+		return "", doc, false
+	}
+
+	end := strings.Index(doc, "\n")
+	if end < 0 {
+		return doc, "", true
+	}
+
+	title = doc[:end]
+	rest = strings.TrimLeft(doc[end+1:], "\n")
+
+	return title, rest, true
+}
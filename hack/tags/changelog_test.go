@@ -0,0 +1,93 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInsertChangelogSectionWithTitle(t *testing.T) {
+	existing := "# Changelog\n\n## 1.1.0\n\n- old entry\n"
+	section := "## 1.2.0\n\n### Features\n\n- new entry\n"
+
+	got := insertChangelogSection(existing, section)
+	want := "# Changelog\n\n## 1.2.0\n\n### Features\n\n- new entry\n\n## 1.1.0\n\n- old entry\n"
+
+	if got != want {
+		t.Errorf("insertChangelogSection() = %q, want %q", got, want)
+	}
+}
+
+func TestInsertChangelogSectionNoTitle(t *testing.T) {
+	existing := "## 1.1.0\n\n- old entry\n"
+	section := "## 1.2.0\n\n- new entry\n"
+
+	got := insertChangelogSection(existing, section)
+	want := "## 1.2.0\n\n- new entry\n\n## 1.1.0\n\n- old entry\n"
+
+	if got != want {
+		t.Errorf("insertChangelogSection() = %q, want %q", got, want)
+	}
+}
+
+func TestInsertChangelogSectionEmptyFile(t *testing.T) {
+	section := "## 1.0.0\n\n- first entry\n"
+
+	got := insertChangelogSection("", section)
+	want := section + "\n"
+
+	if got != want {
+		t.Errorf("insertChangelogSection() = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateChangelogDryRunDoesNotWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	if err := updateChangelog(path, "## 1.2.0\n\n- new entry\n", true); err != nil {
+		t.Fatalf("updateChangelog() error = %v", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("updateChangelog(dryRun=true) should not create %s, stat err = %v", path, err)
+	}
+}
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		subject  string
+		body     string
+		wantOK   bool
+		wantType string
+		wantBrk  bool
+	}{
+		{"feat(api): add widgets", "", true, "feat", false},
+		{"fix: correct overflow", "", true, "fix", false},
+		{"feat!: drop legacy flag", "", true, "feat", true},
+		{"fix(core): patch\n\nBREAKING CHANGE: removes X", "BREAKING CHANGE: removes X", true, "fix", true},
+		{"not a conventional commit", "", false, "", false},
+	}
+
+	for _, tt := range tests {
+		commit, ok := parseConventionalCommit("abc123", tt.subject, tt.body)
+		if ok != tt.wantOK {
+			t.Errorf("parseConventionalCommit(%q) ok = %v, want %v", tt.subject, ok, tt.wantOK)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		if commit.Type != tt.wantType {
+			t.Errorf("parseConventionalCommit(%q).Type = %q, want %q", tt.subject, commit.Type, tt.wantType)
+		}
+
+		if commit.Breaking != tt.wantBrk {
+			t.Errorf("parseConventionalCommit(%q).Breaking = %v, want %v", tt.subject, commit.Breaking, tt.wantBrk)
+		}
+	}
+}
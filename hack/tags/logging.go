@@ -0,0 +1,57 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// logger is the tool's structured logger, configured from -log-format
+// and -log-level in main before any other work runs. All progress and
+// error reporting goes through it to stderr, keeping stdout free for
+// -output=json.
+var logger = slog.Default()
+
+// Result is the final machine-readable summary printed to stdout when
+// -output=json is set.
+type Result struct {
+	Previous string `json:"previous"`
+	Next     string `json:"next"`
+	File     string `json:"file"`
+	Action   string `json:"action"`
+}
+
+func newLogger(format string, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+
+	switch level {
+	case "debug":
+		lvl = slog.LevelDebug
+	case "info":
+		lvl = slog.LevelInfo
+	case "warn":
+		lvl = slog.LevelWarn
+	case "error":
+		lvl = slog.LevelError
+	default:
+		return nil, fmt.Errorf("unknown -log-level: %s", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+
+	switch format {
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		return nil, fmt.Errorf("unknown -log-format: %s", format)
+	}
+
+	return slog.New(handler), nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "testing"
+
+func TestNewLoggerValid(t *testing.T) {
+	formats := []string{"text", "json"}
+	levels := []string{"debug", "info", "warn", "error"}
+
+	for _, format := range formats {
+		for _, level := range levels {
+			if _, err := newLogger(format, level); err != nil {
+				t.Errorf("newLogger(%q, %q) returned error: %v", format, level, err)
+			}
+		}
+	}
+}
+
+func TestNewLoggerUnknownFormat(t *testing.T) {
+	if _, err := newLogger("xml", "info"); err == nil {
+		t.Error("newLogger(\"xml\", \"info\") succeeded, want error")
+	}
+}
+
+func TestNewLoggerUnknownLevel(t *testing.T) {
+	if _, err := newLogger("text", "trace"); err == nil {
+		t.Error("newLogger(\"text\", \"trace\") succeeded, want error")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const pseudoVersionTimestampFormat = "20060102150405"
+
+var (
+	// ErrDirtyWorkingTree is returned by PseudoVersion when the working
+	// tree has uncommitted changes and -allow-dirty was not set.
+	ErrDirtyWorkingTree = errors.New("working tree is dirty; rerun with -allow-dirty")
+	// ErrNoReleaseTag is returned when no SemVer tag is reachable from HEAD.
+	ErrNoReleaseTag = errors.New("no release tag reachable from HEAD")
+	// ErrBaseNotAncestor is returned when the chosen base tag is not an
+	// ancestor of HEAD, which should be impossible given how it's chosen.
+	ErrBaseNotAncestor = errors.New("base tag is not an ancestor of HEAD")
+)
+
+// PseudoVersion computes the canonical Go module pseudo-version for
+// HEAD in the git repository rooted at dir: "vX.Y.Z-0.TIMESTAMP-REV", or
+// "vX.Y.Z-PRE.0.TIMESTAMP-REV" when the nearest reachable release tag is
+// itself a pre-release. TIMESTAMP is HEAD's committer time in UTC and
+// REV is the 12-character commit hash prefix, matching what `go get`
+// would derive for this commit.
+func PseudoVersion(dir string, allowDirty bool) (string, error) {
+	if !allowDirty {
+		dirty, err := isDirty(dir)
+		if err != nil {
+			return "", err
+		}
+
+		if dirty {
+			return "", ErrDirtyWorkingTree
+		}
+	}
+
+	tag, base, err := lastReleaseTag(dir)
+	if err != nil {
+		return "", err
+	}
+
+	ancestor, err := isAncestor(dir, tag, "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	if !ancestor {
+		return "", ErrBaseNotAncestor
+	}
+
+	commitTime, revision, err := headCommitInfo(dir)
+	if err != nil {
+		return "", err
+	}
+
+	timestamp := commitTime.UTC().Format(pseudoVersionTimestampFormat)
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+
+	if len(base.PreRelease) > 0 {
+		return fmt.Sprintf("v%d.%d.%d-%s.0.%s-%s",
+			base.Major, base.Minor, base.Patch, strings.Join(base.PreRelease, "."), timestamp, revision), nil
+	}
+
+	// No intervening pre-release tag: the pseudo-version's numbered part
+	// must be exactly one release ahead of the nearest reachable tag.
+	next := base.BumpPatch()
+
+	return fmt.Sprintf("v%d.%d.%d-0.%s-%s", next.Major, next.Minor, next.Patch, timestamp, revision), nil
+}
+
+// lastReleaseTag returns the highest-precedence SemVer tag reachable
+// from HEAD, by SemVer 2.0.0 ordering rather than git's creation-order
+// `git describe`.
+func lastReleaseTag(dir string) (string, Version, error) {
+	cmd := exec.Command("git", "tag", "--merged", "HEAD")
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", Version{}, fmt.Errorf("git tag --merged HEAD: %w", err)
+	}
+
+	var bestTag string
+	var best Version
+	found := false
+
+	for _, tag := range strings.Fields(string(out)) {
+		version, err := ParseVersion(tag)
+		if err != nil {
+			continue // not a SemVer tag, e.g. a branch-cut marker
+		}
+
+		if !found || version.Compare(best) > 0 {
+			best = version
+			bestTag = tag
+			found = true
+		}
+	}
+
+	if !found {
+		return "", Version{}, ErrNoReleaseTag
+	}
+
+	return bestTag, best, nil
+}
+
+func isAncestor(dir string, ancestor string, ref string) (bool, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, ref)
+	cmd.Dir = dir
+
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("git merge-base --is-ancestor %s %s: %w", ancestor, ref, err)
+}
+
+func isDirty(dir string) (bool, error) {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("git status --porcelain: %w", err)
+	}
+
+	return len(strings.TrimSpace(string(out))) > 0, nil
+}
+
+func headCommitInfo(dir string) (time.Time, string, error) {
+	cmd := exec.Command("git", "show", "-s", "--format=%H %cI", "HEAD")
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("git show HEAD: %w", err)
+	}
+
+	fields := strings.Fields(strings.TrimSpace(string(out)))
+	if len(fields) != 2 {
+		return time.Time{}, "", fmt.Errorf("unexpected git show output: %q", string(out))
+	}
+
+	commitTime, err := time.Parse(time.RFC3339, fields[1])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("parse committer time %q: %w", fields[1], err)
+	}
+
+	return commitTime, fields[0], nil
+}
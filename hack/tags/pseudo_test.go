@@ -0,0 +1,125 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initTestRepo creates a throwaway git repository in t.TempDir(), runs
+// the given git commands (each a slice of arguments, without the
+// leading "git") against it in order, and returns its path.
+func initTestRepo(t *testing.T, commands ...[]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(cmd.Env,
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+
+	for _, args := range commands {
+		run(args...)
+	}
+
+	return dir
+}
+
+func commitFile(message string) []string {
+	return []string{"commit", "--allow-empty", "-q", "-m", message}
+}
+
+func tagHEAD(name string) []string {
+	return []string{"tag", name}
+}
+
+func TestLastReleaseTagPicksHighestPrecedence(t *testing.T) {
+	dir := initTestRepo(t,
+		commitFile("c1"),
+		tagHEAD("v1.0.0"),
+		commitFile("c2"),
+		tagHEAD("v1.2.0"),
+		commitFile("c3"),
+		tagHEAD("not-a-semver-tag"),
+	)
+
+	tag, version, err := lastReleaseTag(dir)
+	if err != nil {
+		t.Fatalf("lastReleaseTag() error = %v", err)
+	}
+
+	if tag != "v1.2.0" {
+		t.Errorf("tag = %q, want v1.2.0", tag)
+	}
+	if version.String() != "1.2.0" {
+		t.Errorf("version = %q, want 1.2.0", version.String())
+	}
+}
+
+func TestLastReleaseTagNoTags(t *testing.T) {
+	dir := initTestRepo(t, commitFile("c1"))
+
+	if _, _, err := lastReleaseTag(dir); err != ErrNoReleaseTag {
+		t.Errorf("lastReleaseTag() error = %v, want ErrNoReleaseTag", err)
+	}
+}
+
+func TestIsDirty(t *testing.T) {
+	dir := initTestRepo(t, commitFile("c1"))
+
+	dirty, err := isDirty(dir)
+	if err != nil {
+		t.Fatalf("isDirty() error = %v", err)
+	}
+	if dirty {
+		t.Errorf("isDirty() = true, want false for a clean repo")
+	}
+}
+
+func TestPseudoVersionRejectsDirtyTree(t *testing.T) {
+	dir := initTestRepo(t, commitFile("c1"))
+
+	if err := os.WriteFile(filepath.Join(dir, "untracked.txt"), []byte("dirty"), 0644); err != nil {
+		t.Fatalf("write untracked file: %v", err)
+	}
+
+	if _, err := PseudoVersion(dir, false); err != ErrDirtyWorkingTree {
+		t.Errorf("PseudoVersion() error = %v, want ErrDirtyWorkingTree", err)
+	}
+}
+
+func TestPseudoVersionFormatsPostReleaseCommit(t *testing.T) {
+	dir := initTestRepo(t,
+		commitFile("c1"),
+		tagHEAD("v1.2.3"),
+		commitFile("c2"),
+	)
+
+	version, err := PseudoVersion(dir, false)
+	if err != nil {
+		t.Fatalf("PseudoVersion() error = %v", err)
+	}
+
+	if !strings.HasPrefix(version, "v1.2.4-0.") {
+		t.Errorf("PseudoVersion() = %q, want prefix v1.2.4-0.", version)
+	}
+}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -17,8 +18,6 @@ import (
 )
 
 const (
-	// DefaultTagVersion used after tagging a GA release
-	DefaultTagVersion string = "dev.1"
 	// DevFullPathFilename filename
 	DevFullPathFilename string = "hack/DEV_BUILD_VERSION.yaml"
 	// NewVersionFullPathFilename filename
@@ -26,85 +25,462 @@ const (
 
 	// NumberOfSemVerSeparators is 3
 	NumberOfSemVerSeparators int = 3
-	// NumberOfSeparatorsInDevTag is 2
-	NumberOfSeparatorsInDevTag int = 2
+
+	// BumpMajor bumps the major version
+	BumpMajor string = "major"
+	// BumpMinor bumps the minor version
+	BumpMinor string = "minor"
+	// BumpPatch bumps the patch version
+	BumpPatch string = "patch"
+	// BumpPreRelease bumps the pre-release identifier
+	BumpPreRelease string = "prerelease"
+
+	// FormatDev persists the dev build as a plain dev.N pre-release
+	FormatDev string = "dev"
+	// FormatPseudo additionally persists a Go-module-compatible pseudo-version
+	FormatPseudo string = "pseudo"
 )
 
 var (
 	// ErrInvalidVersionFormat is Invalid version format
 	ErrInvalidVersionFormat = errors.New("invalid version format")
+	// ErrInvalidBumpKind is an unrecognized -bump value
+	ErrInvalidBumpKind = errors.New("invalid bump kind")
+	// ErrInvalidPreReleaseLabel is an unrecognized -prerelease value
+	ErrInvalidPreReleaseLabel = errors.New("invalid prerelease label")
 	// ErrDataReaderFailed is Datawriter is empty
 	ErrDataReaderFailed = errors.New("datareader is empty")
 	// ErrDataWriterFailed is Datawriter is empty
 	ErrDataWriterFailed = errors.New("datawriter is empty")
+	// ErrVersionNotIncreasing is returned when the version incrementRelease
+	// computed does not have strictly higher SemVer 2.0.0 precedence than
+	// the current tag it was computed from.
+	ErrVersionNotIncreasing = errors.New("computed version does not exceed current tag")
+
+	// DefaultTagVersion is written to DEV_BUILD_VERSION.yaml right after a
+	// GA release, before the next dev cycle begins.
+	DefaultTagVersion = Version{PreRelease: []string{"dev", "1"}}
 )
 
+// Version is a parsed SemVer 2.0.0 version: MAJOR.MINOR.PATCH, with
+// optional dotted pre-release and build metadata identifiers.
 type Version struct {
-	Version string `json:"version"`
+	Major      int      `json:"major"`
+	Minor      int      `json:"minor"`
+	Patch      int      `json:"patch"`
+	PreRelease []string `json:"preRelease,omitempty"`
+	Build      []string `json:"build,omitempty"`
+	// Pseudo is the Go-module-compatible pseudo-version for this dev
+	// build, set only when -format=pseudo was requested.
+	Pseudo string `json:"pseudo,omitempty"`
+}
+
+// ParseVersion parses a SemVer 2.0.0 version string, e.g.
+// "1.2.0-rc.1+build.42". A leading "v" is tolerated.
+func ParseVersion(tag string) (Version, error) {
+	tag = strings.TrimPrefix(tag, "v")
+
+	var build string
+	var hasBuild bool
+	if idx := strings.Index(tag, "+"); idx >= 0 {
+		build = tag[idx+1:]
+		hasBuild = true
+		tag = tag[:idx]
+	}
+
+	var preRelease string
+	var hasPreRelease bool
+	if idx := strings.Index(tag, "-"); idx >= 0 {
+		preRelease = tag[idx+1:]
+		hasPreRelease = true
+		tag = tag[:idx]
+	}
+
+	core := strings.Split(tag, ".")
+	if len(core) != NumberOfSemVerSeparators {
+		return Version{}, ErrInvalidVersionFormat
+	}
+
+	if !validNumericCore(core[0]) || !validNumericCore(core[1]) || !validNumericCore(core[2]) {
+		return Version{}, ErrInvalidVersionFormat
+	}
+
+	major, err := strconv.Atoi(core[0])
+	if err != nil {
+		return Version{}, ErrInvalidVersionFormat
+	}
+
+	minor, err := strconv.Atoi(core[1])
+	if err != nil {
+		return Version{}, ErrInvalidVersionFormat
+	}
+
+	patch, err := strconv.Atoi(core[2])
+	if err != nil {
+		return Version{}, ErrInvalidVersionFormat
+	}
+
+	version := Version{Major: major, Minor: minor, Patch: patch}
+
+	if hasPreRelease {
+		if preRelease == "" {
+			return Version{}, ErrInvalidVersionFormat
+		}
+
+		version.PreRelease = strings.Split(preRelease, ".")
+		for _, id := range version.PreRelease {
+			if !validPreReleaseIdentifier(id) {
+				return Version{}, ErrInvalidVersionFormat
+			}
+		}
+	}
+
+	if hasBuild {
+		if build == "" {
+			return Version{}, ErrInvalidVersionFormat
+		}
+
+		version.Build = strings.Split(build, ".")
+		for _, id := range version.Build {
+			if !validBuildIdentifier(id) {
+				return Version{}, ErrInvalidVersionFormat
+			}
+		}
+	}
+
+	return version, nil
+}
+
+// validNumericCore reports whether s is a valid SemVer 2.0.0
+// major/minor/patch component: digits only, with no leading zero
+// unless the value is exactly "0".
+func validNumericCore(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return s == "0" || s[0] != '0'
+}
+
+// validBuildIdentifier reports whether s is a valid SemVer 2.0.0 build
+// metadata identifier: non-empty ASCII alphanumerics and hyphens only.
+func validBuildIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if !isAlphanumericOrHyphen(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validPreReleaseIdentifier reports whether s is a valid SemVer 2.0.0
+// pre-release identifier: non-empty ASCII alphanumerics and hyphens,
+// and if purely numeric, no leading zero unless the value is "0".
+func validPreReleaseIdentifier(s string) bool {
+	if !validBuildIdentifier(s) {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return true // contains a non-digit, so the leading-zero rule doesn't apply
+		}
+	}
+
+	return s == "0" || s[0] != '0'
+}
+
+func isAlphanumericOrHyphen(r rune) bool {
+	switch {
+	case r >= '0' && r <= '9':
+		return true
+	case r >= 'A' && r <= 'Z':
+		return true
+	case r >= 'a' && r <= 'z':
+		return true
+	case r == '-':
+		return true
+	default:
+		return false
+	}
+}
+
+// String renders the version back into SemVer 2.0.0 form.
+func (v Version) String() string {
+	str := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if len(v.PreRelease) > 0 {
+		str += "-" + strings.Join(v.PreRelease, ".")
+	}
+
+	if len(v.Build) > 0 {
+		str += "+" + strings.Join(v.Build, ".")
+	}
+
+	return str
+}
+
+// Compare returns -1, 0, or 1 if v has lower, equal, or higher SemVer
+// 2.0.0 precedence than other. Build metadata is ignored, per spec.
+func (v Version) Compare(other Version) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePreRelease implements SemVer 2.0.0 precedence rules for
+// pre-release identifiers: a version without a pre-release outranks one
+// with, identifiers are compared left to right, numeric identifiers
+// always sort lower than alphanumeric ones, and a longer identifier
+// list outranks a shorter one that otherwise matches.
+func comparePreRelease(a, b []string) int {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+
+	if len(a) == 0 {
+		return 1
+	}
+
+	if len(b) == 0 {
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := comparePreReleaseIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInt(len(a), len(b))
+}
+
+func comparePreReleaseIdentifier(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+
+	// Atoi accepts a leading sign, but only all-digit identifiers are numeric.
+	aNumeric := aErr == nil && strings.Trim(a, "0123456789") == ""
+	bNumeric := bErr == nil && strings.Trim(b, "0123456789") == ""
+
+	switch {
+	case aNumeric && bNumeric:
+		return compareInt(an, bn)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// BumpMajor increments the major version and resets minor/patch, or
+// finalizes v to its own major.minor.patch if it is already a
+// pre-release.
+func (v Version) BumpMajor() Version {
+	if len(v.PreRelease) > 0 {
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	}
+
+	return Version{Major: v.Major + 1}
+}
+
+// BumpMinor increments the minor version and resets patch, or finalizes
+// v if it is already a pre-release.
+func (v Version) BumpMinor() Version {
+	if len(v.PreRelease) > 0 {
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	}
+
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// BumpPatch increments the patch version, or finalizes v if it is
+// already a pre-release.
+func (v Version) BumpPatch() Version {
+	if len(v.PreRelease) > 0 {
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	}
+
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+// BumpPreRelease advances the pre-release identifier for label (e.g.
+// "rc", "beta", "alpha", "dev"), keeping major/minor/patch unchanged. If
+// v is already a pre-release under the same label, the trailing numeric
+// identifier is incremented; otherwise the pre-release starts at
+// label.1.
+func (v Version) BumpPreRelease(label string) Version {
+	next := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+
+	if len(v.PreRelease) >= 2 && v.PreRelease[0] == label {
+		if n, err := strconv.Atoi(v.PreRelease[len(v.PreRelease)-1]); err == nil {
+			next.PreRelease = append(append([]string{}, v.PreRelease[:len(v.PreRelease)-1]...), strconv.Itoa(n+1))
+			return next
+		}
+	}
+
+	next.PreRelease = []string{label, "1"}
+
+	return next
+}
+
+func validPreReleaseLabel(label string) bool {
+	switch label {
+	case "rc", "beta", "alpha":
+		return true
+	default:
+		return false
+	}
 }
 
 // Release version
-func newRelease(current string) error {
-	newVersion, err := incrementRelease(current)
+func newRelease(current string, bump string, preRelease string, repoURL string, dryRun bool, force bool) (Version, error) {
+	commits, err := conventionalCommits(".", current+"..HEAD")
 	if err != nil {
-		fmt.Printf("incrementRelease failed. Err: %v\n", err)
-		return err
+		logger.Error("conventionalCommits failed", "op", "newRelease", "from", current, "err", err)
+		return Version{}, err
+	}
+
+	if hasBreakingChange(commits) && bump == BumpMinor && !force {
+		logger.Error("breaking change requires a major bump", "op", "newRelease", "from", current, "bump", bump)
+		return Version{}, ErrBreakingChangeRequiresMajor
+	}
+
+	newVersion, err := incrementRelease(current, bump, preRelease)
+	if err != nil {
+		logger.Error("incrementRelease failed", "op", "newRelease", "from", current, "err", err)
+		return Version{}, err
 	}
 
 	err = saveRelease(newVersion)
 	if err != nil {
-		fmt.Printf("saveDev failed. Err: %v\n", err)
-		return err
+		logger.Error("saveRelease failed", "op", "newRelease", "to", newVersion.String(), "err", err)
+		return Version{}, err
 	}
 
-	return nil
+	err = updateChangelog(ChangelogFullPathFilename, renderChangelogSection(newVersion, commits, repoURL), dryRun)
+	if err != nil {
+		logger.Error("updateChangelog failed", "op", "newRelease", "file", ChangelogFullPathFilename, "err", err)
+		return Version{}, err
+	}
+
+	return newVersion, nil
 }
 
-func incrementRelease(tag string) (string, error) {
-	items := strings.Split(tag, ".")
-	if len(items) != NumberOfSemVerSeparators {
-		fmt.Printf("Split version failed\n")
-		return "", ErrInvalidVersionFormat
+func incrementRelease(tag string, bump string, preRelease string) (Version, error) {
+	version, err := ParseVersion(tag)
+	if err != nil {
+		logger.Error("ParseVersion failed", "op", "incrementRelease", "tag", tag, "err", err)
+		return Version{}, err
 	}
 
-	ver, err := strconv.Atoi(items[1])
-	if err != nil {
-		fmt.Printf("String to int failed\n")
-		return "", ErrInvalidVersionFormat
+	if preRelease != "" && !validPreReleaseLabel(preRelease) {
+		logger.Error("invalid -prerelease", "op", "incrementRelease", "prerelease", preRelease)
+		return Version{}, ErrInvalidPreReleaseLabel
+	}
+
+	var newVersion Version
+
+	switch bump {
+	case BumpMajor:
+		newVersion = version.BumpMajor()
+	case BumpMinor:
+		newVersion = version.BumpMinor()
+	case BumpPatch:
+		newVersion = version.BumpPatch()
+	case BumpPreRelease:
+		if preRelease == "" {
+			logger.Error("-prerelease is required when -bump=prerelease", "op", "incrementRelease")
+			return Version{}, ErrInvalidPreReleaseLabel
+		}
+
+		newVersion = version.BumpPreRelease(preRelease)
+	default:
+		logger.Error("unknown -bump", "op", "incrementRelease", "bump", bump)
+		return Version{}, ErrInvalidBumpKind
 	}
 
-	newMajor := items[0]
-	newMinor := ver + 1
-	newVersionStr := fmt.Sprintf("%s.%d.0", newMajor, newMinor)
-	fmt.Printf("incrementRelease: %s\n", newVersionStr)
+	if preRelease != "" && bump != BumpPreRelease {
+		if len(version.PreRelease) > 0 && version.PreRelease[0] == preRelease {
+			// version is already a pre-release under this same label, so
+			// Bump{Major,Minor,Patch} above just finalized it to the same
+			// major.minor.patch rather than advancing anything. Continue
+			// that series instead of restarting it at label.1, which would
+			// regress precedence below version.
+			newVersion = version.BumpPreRelease(preRelease)
+		} else {
+			newVersion = newVersion.BumpPreRelease(preRelease)
+		}
+	}
 
-	return newVersionStr, nil
+	if newVersion.Compare(version) <= 0 {
+		logger.Error("computed version does not exceed current", "op", "incrementRelease", "from", version.String(), "to", newVersion.String())
+		return Version{}, ErrVersionNotIncreasing
+	}
+
+	logger.Info("incrementRelease", "op", "incrementRelease", "from", version.String(), "to", newVersion.String())
+
+	return newVersion, nil
 }
 
-func saveRelease(version string) error {
+func saveRelease(version Version) error {
 	// write the file
 	fileWrite, err := os.OpenFile(NewVersionFullPathFilename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
 	if err != nil {
-		fmt.Printf("Open for write failed. Err: %v\n", err)
+		logger.Error("open for write failed", "op", "saveRelease", "file", NewVersionFullPathFilename, "err", err)
 		return err
 	}
 
 	datawriter := bufio.NewWriter(fileWrite)
 	if datawriter == nil {
-		fmt.Printf("Datawriter creation failed\n")
+		logger.Error("datawriter creation failed", "op", "saveRelease", "file", NewVersionFullPathFilename)
 		return ErrDataWriterFailed
 	}
 
-	_, err = datawriter.Write([]byte(version))
+	_, err = datawriter.Write([]byte(version.String()))
 	if err != nil {
-		fmt.Printf("datawriter.Write error. Err: %v\n", err)
+		logger.Error("datawriter.Write failed", "op", "saveRelease", "file", NewVersionFullPathFilename, "err", err)
 		return err
 	}
 	datawriter.Flush()
 
 	err = fileWrite.Close()
 	if err != nil {
-		fmt.Printf("fileWrite.Close failed. Err: %v\n", err)
+		logger.Error("fileWrite.Close failed", "op", "saveRelease", "file", NewVersionFullPathFilename, "err", err)
 		return err
 	}
 
@@ -118,114 +494,106 @@ func resetDev() error {
 	return saveDev(DefaultTagVersion)
 }
 
-func bumpDev() error {
+func bumpDev(format string, allowDirty bool) error {
 	version, err := getTag()
 	if err != nil {
-		fmt.Printf("getTag failed. Err: %v\n", err)
+		logger.Error("getTag failed", "op", "bumpDev", "file", DevFullPathFilename, "err", err)
 		return err
 	}
 
 	newVersion, err := incrementDev(version)
 	if err != nil {
-		fmt.Printf("incrementDev failed. Err: %v\n", err)
+		logger.Error("incrementDev failed", "op", "bumpDev", "err", err)
 		return err
 	}
 
+	if format == FormatPseudo {
+		pseudo, err := PseudoVersion(".", allowDirty)
+		if err != nil {
+			logger.Error("PseudoVersion failed", "op", "bumpDev", "err", err)
+			return err
+		}
+
+		newVersion.Pseudo = pseudo
+	}
+
 	err = saveDev(newVersion)
 	if err != nil {
-		fmt.Printf("saveDev failed. Err: %v\n", err)
+		logger.Error("saveDev failed", "op", "bumpDev", "file", DevFullPathFilename, "err", err)
 		return err
 	}
 
 	return nil
 }
 
-func getTag() (string, error) {
+func getTag() (Version, error) {
 	fileRead, err := os.OpenFile(DevFullPathFilename, os.O_RDONLY, 0755)
 	if err != nil {
-		fmt.Printf("Open for read failed. Err: %v\n", err)
-		return "", err
+		logger.Error("open for read failed", "op", "getTag", "file", DevFullPathFilename, "err", err)
+		return Version{}, err
 	}
 
 	dataReader := bufio.NewReader(fileRead)
 	if dataReader == nil {
-		fmt.Printf("Datareader creation failed\n")
-		return "", ErrDataReaderFailed
+		logger.Error("datareader creation failed", "op", "getTag", "file", DevFullPathFilename)
+		return Version{}, ErrDataReaderFailed
 	}
 
 	byFile, err := io.ReadAll(dataReader)
 	if err != nil {
-		fmt.Printf("ReadAll failed. Err: %v\n", err)
-		return "", err
+		logger.Error("ReadAll failed", "op", "getTag", "file", DevFullPathFilename, "err", err)
+		return Version{}, err
 	}
 
-	version := &Version{}
+	version := Version{}
 
-	err = yaml.Unmarshal(byFile, version)
+	err = yaml.Unmarshal(byFile, &version)
 	if err != nil {
-		fmt.Printf("Unmarshal failed. Err: %v\n", err)
-		return "", err
+		logger.Error("Unmarshal failed", "op", "getTag", "file", DevFullPathFilename, "err", err)
+		return Version{}, err
 	}
 
-	return version.Version, nil
+	return version, nil
 }
 
-func incrementDev(tag string) (string, error) {
-	items := strings.Split(tag, ".")
-	if len(items) != NumberOfSeparatorsInDevTag {
-		fmt.Printf("Split version failed\n")
-		return "", ErrInvalidVersionFormat
-	}
-
-	ver, err := strconv.Atoi(items[1])
-	if err != nil {
-		fmt.Printf("String to int failed\n")
-		return "", ErrInvalidVersionFormat
-	}
+func incrementDev(version Version) (Version, error) {
+	newVersion := version.BumpPreRelease("dev")
+	logger.Info("incrementDev", "op", "incrementDev", "from", version.String(), "to", newVersion.String())
 
-	newVersion := ver + 1
-	newVersionStr := fmt.Sprintf("dev.%d", newVersion)
-	fmt.Printf("incrementDev: %s\n", newVersionStr)
-
-	return newVersionStr, nil
+	return newVersion, nil
 }
 
-func saveDev(tag string) error {
-	version := &Version{
-		Version: tag,
-	}
-
+func saveDev(version Version) error {
 	byRaw, err := yaml.Marshal(version)
 	if err != nil {
-		fmt.Printf("yaml.Marshal error. Err: %v\n", err)
+		logger.Error("yaml.Marshal failed", "op", "saveDev", "err", err)
 		return err
 	}
-	fmt.Printf("BYTES:\n\n")
-	fmt.Printf("%s\n", string(byRaw))
+	logger.Debug("saveDev", "op", "saveDev", "file", DevFullPathFilename, "bytes", string(byRaw))
 
 	// write the file
 	fileWrite, err := os.OpenFile(DevFullPathFilename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
 	if err != nil {
-		fmt.Printf("Open for write failed. Err: %v\n", err)
+		logger.Error("open for write failed", "op", "saveDev", "file", DevFullPathFilename, "err", err)
 		return err
 	}
 
 	datawriter := bufio.NewWriter(fileWrite)
 	if datawriter == nil {
-		fmt.Printf("Datawriter creation failed\n")
+		logger.Error("datawriter creation failed", "op", "saveDev", "file", DevFullPathFilename)
 		return ErrDataWriterFailed
 	}
 
 	_, err = datawriter.Write(byRaw)
 	if err != nil {
-		fmt.Printf("datawriter.Write error. Err: %v\n", err)
+		logger.Error("datawriter.Write failed", "op", "saveDev", "file", DevFullPathFilename, "err", err)
 		return err
 	}
 	datawriter.Flush()
 
 	err = fileWrite.Close()
 	if err != nil {
-		fmt.Printf("fileWrite.Close failed. Err: %v\n", err)
+		logger.Error("fileWrite.Close failed", "op", "saveDev", "file", DevFullPathFilename, "err", err)
 		return err
 	}
 
@@ -242,35 +610,103 @@ func main() {
 	var release bool
 	flag.BoolVar(&release, "release", false, "Is this a release")
 
+	var bump string
+	flag.StringVar(&bump, "bump", BumpMinor, "Which part of the version to bump: major, minor, patch, or prerelease")
+
+	var preRelease string
+	flag.StringVar(&preRelease, "prerelease", "", "Pre-release label to apply or advance: rc, beta, or alpha")
+
+	var repoURL string
+	flag.StringVar(&repoURL, "repo-url", "", "Repository URL used to hyperlink commit SHAs in CHANGELOG.md")
+
+	var dryRun bool
+	flag.BoolVar(&dryRun, "dry-run", false, "Print the CHANGELOG.md update instead of writing it")
+
+	var force bool
+	flag.BoolVar(&force, "force", false, "Allow a minor bump even when breaking changes are present")
+
+	var format string
+	flag.StringVar(&format, "format", FormatDev, "Dev build version format: dev or pseudo")
+
+	var allowDirty bool
+	flag.BoolVar(&allowDirty, "allow-dirty", false, "Allow -format=pseudo to run against a dirty working tree")
+
+	var logFormat string
+	flag.StringVar(&logFormat, "log-format", "text", "Log handler: text or json")
+
+	var logLevel string
+	flag.StringVar(&logLevel, "log-level", "info", "Log level: debug, info, warn, or error")
+
+	var output string
+	flag.StringVar(&output, "output", "", "Set to json to print a machine-readable result object to stdout")
+
 	flag.Parse()
 	// flags
 
+	var err error
+	logger, err = newLogger(logFormat, logLevel)
+	if err != nil {
+		fmt.Printf("newLogger failed. Err: %v\n", err)
+		os.Exit(1)
+	}
+
+	result := Result{Previous: tag}
+
 	if release {
 		if tag == "" {
-			fmt.Printf("Must supply -tag when -release is set\n")
-			return
+			logger.Error("must supply -tag when -release is set")
+			os.Exit(1)
 		}
 
-		fmt.Printf("Cutting GA release, so resetting\n")
-		err := resetDev()
-		if err != nil {
-			fmt.Printf("resetDev failed. Err: %v\n", err)
-			return
+		logger.Info("cutting GA release, so resetting")
+
+		if err := resetDev(); err != nil {
+			logger.Error("resetDev failed", "err", err)
+			os.Exit(1)
 		}
 
-		err = newRelease(tag)
+		newVersion, err := newRelease(tag, bump, preRelease, repoURL, dryRun, force)
 		if err != nil {
-			fmt.Printf("newRelease failed. Err: %v\n", err)
-			return
+			logger.Error("newRelease failed", "err", err)
+			os.Exit(1)
 		}
+
+		result.Action = "release"
+		result.Next = newVersion.String()
+		result.File = NewVersionFullPathFilename
 	} else {
-		fmt.Printf("Cutting RC release, so bumping\n")
-		err := bumpDev()
+		logger.Info("cutting RC release, so bumping")
+
+		current, err := getTag()
 		if err != nil {
-			fmt.Printf("bumpDev failed. Err: %v\n", err)
-			return
+			logger.Error("getTag failed", "err", err)
+			os.Exit(1)
 		}
+
+		if err := bumpDev(format, allowDirty); err != nil {
+			logger.Error("bumpDev failed", "err", err)
+			os.Exit(1)
+		}
+
+		newVersion, err := getTag()
+		if err != nil {
+			logger.Error("getTag failed", "err", err)
+			os.Exit(1)
+		}
+
+		result.Action = "bump-dev"
+		result.Previous = current.String()
+		result.Next = newVersion.String()
+		result.File = DevFullPathFilename
 	}
 
-	fmt.Printf("Succeeded\n")
-}
\ No newline at end of file
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		if err := encoder.Encode(result); err != nil {
+			logger.Error("encode result failed", "err", err)
+			os.Exit(1)
+		}
+	} else {
+		logger.Info("succeeded")
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2020-2021 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "testing"
+
+func TestParseVersionValid(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Version
+	}{
+		{"1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"0.0.0", Version{Major: 0, Minor: 0, Patch: 0}},
+		{"1.2.0-rc.1", Version{Major: 1, Minor: 2, Patch: 0, PreRelease: []string{"rc", "1"}}},
+		{"1.2.0-rc.1+build.42", Version{Major: 1, Minor: 2, Patch: 0, PreRelease: []string{"rc", "1"}, Build: []string{"build", "42"}}},
+		{"1.0.0-x-y-z.-", Version{Major: 1, Minor: 0, Patch: 0, PreRelease: []string{"x-y-z", "-"}}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.tag)
+		if err != nil {
+			t.Errorf("ParseVersion(%q) returned error: %v", tt.tag, err)
+			continue
+		}
+
+		if got.String() != tt.want.String() {
+			t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestParseVersionInvalid(t *testing.T) {
+	tests := []string{
+		"1.2",
+		"1.2.3.4",
+		"1.2.x",
+		"01.2.3",
+		"1.02.3",
+		"1.2.03",
+		"1.2.3-01",
+		"1.2.3-@@@",
+		"1.2.3+@@@",
+		"1.2.3-",
+		"1.2.3-rc..1",
+	}
+
+	for _, tag := range tests {
+		if _, err := ParseVersion(tag); err == nil {
+			t.Errorf("ParseVersion(%q) succeeded, want error", tag)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "2.0.0", -1},
+		{"2.0.0", "1.0.0", 1},
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0", "1.0.0-alpha", 1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-beta.2", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseVersion(tt.a)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", tt.a, err)
+		}
+
+		b, err := ParseVersion(tt.b)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", tt.b, err)
+		}
+
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestVersionBump(t *testing.T) {
+	v, err := ParseVersion("1.2.3")
+	if err != nil {
+		t.Fatalf("ParseVersion: %v", err)
+	}
+
+	if got := v.BumpMajor().String(); got != "2.0.0" {
+		t.Errorf("BumpMajor() = %s, want 2.0.0", got)
+	}
+
+	if got := v.BumpMinor().String(); got != "1.3.0" {
+		t.Errorf("BumpMinor() = %s, want 1.3.0", got)
+	}
+
+	if got := v.BumpPatch().String(); got != "1.2.4" {
+		t.Errorf("BumpPatch() = %s, want 1.2.4", got)
+	}
+
+	if got := v.BumpPreRelease("rc").String(); got != "1.2.3-rc.1" {
+		t.Errorf("BumpPreRelease(rc) = %s, want 1.2.3-rc.1", got)
+	}
+}
+
+func TestIncrementReleaseContinuesInProgressPreReleaseSeries(t *testing.T) {
+	// -bump=minor combined with -prerelease=rc, where the current tag is
+	// already a pre-release under that label, must keep advancing that
+	// series (rc.2 -> rc.3) rather than restarting it at rc.1, which
+	// would regress SemVer precedence below the current tag.
+	got, err := incrementRelease("1.2.0-rc.2", BumpMinor, "rc")
+	if err != nil {
+		t.Fatalf("incrementRelease() error = %v", err)
+	}
+
+	if got.String() != "1.2.0-rc.3" {
+		t.Errorf("incrementRelease() = %s, want 1.2.0-rc.3", got.String())
+	}
+
+	current, err := ParseVersion("1.2.0-rc.2")
+	if err != nil {
+		t.Fatalf("ParseVersion: %v", err)
+	}
+
+	if got.Compare(current) <= 0 {
+		t.Errorf("incrementRelease() = %s does not exceed current %s", got.String(), current.String())
+	}
+}
+
+func TestIncrementReleaseRejectsNonIncreasingVersion(t *testing.T) {
+	// Switching to a pre-release label that sorts below the one already
+	// in progress (here "beta" < "rc") would otherwise silently produce a
+	// version with lower precedence than the current tag.
+	if _, err := incrementRelease("1.2.0-rc.2", BumpMinor, "beta"); err != ErrVersionNotIncreasing {
+		t.Errorf("incrementRelease() error = %v, want ErrVersionNotIncreasing", err)
+	}
+}
+
+func TestIncrementReleaseFreshPreRelease(t *testing.T) {
+	got, err := incrementRelease("1.1.0", BumpMinor, "rc")
+	if err != nil {
+		t.Fatalf("incrementRelease() error = %v", err)
+	}
+
+	if got.String() != "1.2.0-rc.1" {
+		t.Errorf("incrementRelease() = %s, want 1.2.0-rc.1", got.String())
+	}
+}
+
+func TestVersionBumpPreReleaseSequence(t *testing.T) {
+	v, err := ParseVersion("1.2.0-rc.1")
+	if err != nil {
+		t.Fatalf("ParseVersion: %v", err)
+	}
+
+	next := v.BumpPreRelease("rc")
+	if got := next.String(); got != "1.2.0-rc.2" {
+		t.Errorf("BumpPreRelease(rc) = %s, want 1.2.0-rc.2", got)
+	}
+
+	if got := next.BumpPatch().String(); got != "1.2.0" {
+		t.Errorf("BumpPatch() finalize = %s, want 1.2.0", got)
+	}
+}